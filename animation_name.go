@@ -0,0 +1,153 @@
+package cof
+
+import (
+	"fmt"
+	"path/filepath"
+	"strings"
+)
+
+// AnimationMode is the two-letter animation-mode token used in COF/DCC
+// filenames, e.g. the "NU" in "PLNUHTH.cof".
+type AnimationMode string
+
+// Animation modes used throughout Diablo II's character and monster
+// animations.
+const (
+	ModeNeutral  AnimationMode = "NU"
+	ModeTown     AnimationMode = "TN"
+	ModeWalk     AnimationMode = "WL"
+	ModeRun      AnimationMode = "RN"
+	ModeGetHit   AnimationMode = "GH"
+	ModeTwitch   AnimationMode = "TW"
+	ModeSkill1   AnimationMode = "A1"
+	ModeSkill2   AnimationMode = "A2"
+	ModeBlock    AnimationMode = "BL"
+	ModeCast     AnimationMode = "SC"
+	ModeThrow    AnimationMode = "TH"
+	ModeKick     AnimationMode = "KK"
+	ModeSpecial1 AnimationMode = "S1"
+	ModeSpecial2 AnimationMode = "S2"
+	ModeSpecial3 AnimationMode = "S3"
+	ModeSpecial4 AnimationMode = "S4"
+	ModeDeath    AnimationMode = "DT"
+	ModeDead     AnimationMode = "DD"
+	ModeSequence AnimationMode = "SQ"
+)
+
+// CharacterToken is the two-letter character/monster token used in COF/DCC
+// filenames, e.g. the "PL" in "PLNUHTH.cof".
+type CharacterToken string
+
+// CharacterToken values for the player classes and a handful of common
+// monster tokens. This list is not exhaustive.
+const (
+	TokenAmazon      CharacterToken = "AM"
+	TokenAssassin    CharacterToken = "AI"
+	TokenBarbarian   CharacterToken = "BA"
+	TokenDruid       CharacterToken = "DZ"
+	TokenFallen      CharacterToken = "FC"
+	TokenHungryDead  CharacterToken = "HC"
+	TokenImp         CharacterToken = "IN"
+	TokenNecromancer CharacterToken = "NE"
+	TokenPaladin     CharacterToken = "PA"
+	TokenSkeleton    CharacterToken = "SK"
+	TokenTreant      CharacterToken = "TR"
+)
+
+const (
+	nameTokenLength = 2
+	nameModeLength  = 2
+)
+
+// ParseName splits a COF filename of the form "<TOK><MODE><WCLASS>.cof"
+// (e.g. "PLNUHTH.cof") into its character token, animation mode, and weapon
+// class.
+func ParseName(filename string) (token, mode string, wc WeaponClass, err error) {
+	base := filepath.Base(filename)
+	base = strings.TrimSuffix(base, filepath.Ext(base))
+
+	minLength := nameTokenLength + nameModeLength
+	if len(base) < minLength {
+		return "", "", wc, fmt.Errorf("filename %q is too short to contain a token and mode", filename)
+	}
+
+	token = strings.ToUpper(base[:nameTokenLength])
+	mode = strings.ToUpper(base[nameTokenLength : nameTokenLength+nameModeLength])
+	wc = WeaponClassFromString(strings.ToUpper(base[nameTokenLength+nameModeLength:]))
+
+	return token, mode, wc, nil
+}
+
+// compositeCodesFullBody is the full 16-slot composite layer set used by
+// every mode where the character is drawn holding its equipped weapon and
+// shield: idle/walk/run/town, getting hit, blocking, kicking, and attack
+// and skill-cast modes.
+var compositeCodesFullBody = []string{
+	"HD", "TR", "LG", "RA", "LA", "RH", "LH", "SH",
+	"S1", "S2", "S3", "S4", "S5", "S6", "S7", "S8",
+}
+
+// compositeCodesNoWeapons drops the weapon/shield layers (RH, LH, SH): the
+// character drops its equipped items on death, so the death animation never
+// draws them.
+var compositeCodesNoWeapons = []string{
+	"HD", "TR", "LG", "RA", "LA",
+	"S1", "S2", "S3", "S4", "S5", "S6", "S7", "S8",
+}
+
+// compositeCodesCorpseOnly is the reduced layer set for a static lying-dead
+// corpse, which has no separate arm or weapon layers.
+var compositeCodesCorpseOnly = []string{"HD", "TR", "LG"}
+
+// compositeCodesSequence is the layer set for one-off full-body sequences
+// (e.g. town portal cast), which composite onto a single body layer.
+var compositeCodesSequence = []string{"TR"}
+
+// modeLayerCodes maps each animation mode to its expected composite layer
+// codes. This is a best-known approximation of the mappings OpenDiablo2's
+// asset manager uses, not a byte-exact spec, but it is differentiated per
+// mode so ModeLayerSet/validateAgainstFilename can actually catch a layer
+// that doesn't belong in a given mode.
+var modeLayerCodes = map[AnimationMode][]string{
+	ModeNeutral:  compositeCodesFullBody,
+	ModeTown:     compositeCodesFullBody,
+	ModeWalk:     compositeCodesFullBody,
+	ModeRun:      compositeCodesFullBody,
+	ModeGetHit:   compositeCodesFullBody,
+	ModeTwitch:   compositeCodesFullBody,
+	ModeSkill1:   compositeCodesFullBody,
+	ModeSkill2:   compositeCodesFullBody,
+	ModeBlock:    compositeCodesFullBody,
+	ModeCast:     compositeCodesFullBody,
+	ModeThrow:    compositeCodesFullBody,
+	ModeKick:     compositeCodesFullBody,
+	ModeSpecial1: compositeCodesFullBody,
+	ModeSpecial2: compositeCodesFullBody,
+	ModeSpecial3: compositeCodesFullBody,
+	ModeSpecial4: compositeCodesFullBody,
+	ModeDeath:    compositeCodesNoWeapons,
+	ModeDead:     compositeCodesCorpseOnly,
+	ModeSequence: compositeCodesSequence,
+}
+
+// ModeLayerSet returns the composite layers expected for the given
+// animation mode. It returns nil for a mode it doesn't recognize.
+func ModeLayerSet(mode AnimationMode) []CompositeType {
+	codes, found := modeLayerCodes[mode]
+	if !found {
+		return nil
+	}
+
+	layers := make([]CompositeType, 0, len(codes))
+
+	for _, code := range codes {
+		compositeType, err := CompositeTypeFromString(code)
+		if err != nil {
+			continue
+		}
+
+		layers = append(layers, compositeType)
+	}
+
+	return layers
+}
@@ -56,6 +56,43 @@ func (ad *AnimationData) GetRecords(name string) []*AnimationDataRecord {
 	return ad.entries[name]
 }
 
+// hashName computes the original Blizzard Diablo II AnimData hash for a
+// record name: the sum of the bytes of the uppercased name, modulo 256.
+func hashName(name string) byte {
+	sum := 0
+
+	for _, r := range strings.ToUpper(name) {
+		sum += int(byte(r))
+	}
+
+	return byte(sum % numBlocks)
+}
+
+// Lookup returns the record whose name hashes into the same block the
+// original engine would have routed it to, matching the game's own lookup
+// semantics (collisions and all) rather than doing a full map lookup by
+// name. It falls back to a linear scan of that single block to resolve
+// collisions. As with GetRecord, if more than one record in the block has
+// the given name, the last one wins.
+func (ad *AnimationData) Lookup(name string) *AnimationDataRecord {
+	blockIdx := int(hashName(name)) % numBlocks
+
+	b := ad.blocks[blockIdx]
+	if b == nil {
+		return nil
+	}
+
+	var found *AnimationDataRecord
+
+	for _, r := range b.records {
+		if r.name == name {
+			found = r
+		}
+	}
+
+	return found
+}
+
 // Load loads the data into an AnimationData struct
 func Load(data []byte) (*AnimationData, error) {
 	reader := bitstream.NewReader().FromBytes(data...)
@@ -89,6 +126,7 @@ func Load(data []byte) (*AnimationData, error) {
 			name = strings.ReplaceAll(name, string(byte(0)), "")
 
 			animdata.hashTable[hashIdx] = hashName(name)
+			hashIdx++
 
 			frames, err := reader.Next(32).Bits().AsUInt32()
 			if err != nil {
@@ -149,3 +187,45 @@ func Load(data []byte) (*AnimationData, error) {
 
 	return animdata, nil
 }
+
+// Marshal encodes this AnimationData back to the AnimData.d2 binary layout.
+func (ad *AnimationData) Marshal() []byte {
+	sw := &bitstream.Writer{}
+
+	for _, b := range ad.blocks {
+		recordCount := uint32(0)
+
+		var records []*AnimationDataRecord
+
+		if b != nil {
+			recordCount = b.recordCount
+			records = b.records
+		}
+
+		sw.WriteBytes(uint32ToBytes(recordCount))
+
+		for _, r := range records {
+			nameBytes := make([]byte, byteCountName)
+			copy(nameBytes, r.name)
+			sw.WriteBytes(nameBytes)
+
+			sw.WriteBytes(uint32ToBytes(r.frames))
+			sw.WriteBytes(uint16ToBytes(r.speed))
+			sw.WriteBytes(make([]byte, byteCountSpeedPadding))
+
+			for eventIdx := 0; eventIdx < numEvents; eventIdx++ {
+				sw.WriteByte(byte(r.events[eventIdx]))
+			}
+		}
+	}
+
+	return sw.Bytes()
+}
+
+func uint32ToBytes(v uint32) []byte {
+	return []byte{byte(v), byte(v >> 8), byte(v >> 16), byte(v >> 24)}
+}
+
+func uint16ToBytes(v uint16) []byte {
+	return []byte{byte(v), byte(v >> 8)}
+}
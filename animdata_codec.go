@@ -0,0 +1,144 @@
+package cof
+
+import (
+	"encoding/json"
+	"strconv"
+)
+
+// animationDataRecordDTO is the JSON/YAML representation of an
+// AnimationDataRecord. Events are keyed by frame index and spelled out by
+// name instead of their raw integer values.
+type animationDataRecordDTO struct {
+	Name   string            `json:"name" yaml:"name"`
+	Frames uint32            `json:"frames" yaml:"frames"`
+	Speed  uint16            `json:"speed" yaml:"speed"`
+	Events map[string]string `json:"events,omitempty" yaml:"events,omitempty"`
+}
+
+// animationDataBlockDTO is the JSON/YAML representation of a single hash
+// block of records, in on-disk order.
+type animationDataBlockDTO struct {
+	Records []animationDataRecordDTO `json:"records" yaml:"records"`
+}
+
+// animationDataDTO is the JSON/YAML representation of an AnimationData. The
+// block grouping is preserved verbatim so a decoded AnimationData marshals
+// back to the original AnimData.d2 bytes.
+type animationDataDTO struct {
+	Blocks []animationDataBlockDTO `json:"blocks" yaml:"blocks"`
+}
+
+func recordToDTO(r *AnimationDataRecord) animationDataRecordDTO {
+	events := make(map[string]string, len(r.events))
+
+	for frameIdx, event := range r.events {
+		events[strconv.Itoa(frameIdx)] = event.String()
+	}
+
+	return animationDataRecordDTO{
+		Name:   r.name,
+		Frames: r.frames,
+		Speed:  r.speed,
+		Events: events,
+	}
+}
+
+func recordFromDTO(dto animationDataRecordDTO) (*AnimationDataRecord, error) {
+	events := make(map[int]FrameEvent, len(dto.Events))
+
+	for frameIdxStr, name := range dto.Events {
+		frameIdx, err := strconv.Atoi(frameIdxStr)
+		if err != nil {
+			return nil, err
+		}
+
+		event, err := FrameEventFromString(name)
+		if err != nil {
+			return nil, err
+		}
+
+		events[frameIdx] = event
+	}
+
+	return &AnimationDataRecord{dto.Name, dto.Frames, dto.Speed, events}, nil
+}
+
+func (ad *AnimationData) toDTO() animationDataDTO {
+	blocks := make([]animationDataBlockDTO, len(ad.blocks))
+
+	for i, b := range ad.blocks {
+		if b == nil {
+			continue
+		}
+
+		records := make([]animationDataRecordDTO, len(b.records))
+		for j, r := range b.records {
+			records[j] = recordToDTO(r)
+		}
+
+		blocks[i] = animationDataBlockDTO{Records: records}
+	}
+
+	return animationDataDTO{Blocks: blocks}
+}
+
+func (ad *AnimationData) fromDTO(dto animationDataDTO) error {
+	ad.entries = make(map[string][]*AnimationDataRecord)
+
+	numBlocksInDTO := len(dto.Blocks)
+	if numBlocksInDTO > numBlocks {
+		numBlocksInDTO = numBlocks
+	}
+
+	for i := 0; i < numBlocksInDTO; i++ {
+		records := make([]*AnimationDataRecord, len(dto.Blocks[i].Records))
+
+		for j, recordDTO := range dto.Blocks[i].Records {
+			record, err := recordFromDTO(recordDTO)
+			if err != nil {
+				return err
+			}
+
+			records[j] = record
+			ad.entries[record.name] = append(ad.entries[record.name], record)
+		}
+
+		ad.blocks[i] = &block{uint32(len(records)), records}
+	}
+
+	return nil
+}
+
+// MarshalJSON encodes this AnimationData as human-readable JSON, preserving
+// block grouping so it can be converted back to AnimData.d2 byte-exact.
+func (ad *AnimationData) MarshalJSON() ([]byte, error) {
+	return json.Marshal(ad.toDTO())
+}
+
+// UnmarshalJSON decodes an AnimationData previously produced by MarshalJSON.
+func (ad *AnimationData) UnmarshalJSON(data []byte) error {
+	var dto animationDataDTO
+
+	if err := json.Unmarshal(data, &dto); err != nil {
+		return err
+	}
+
+	return ad.fromDTO(dto)
+}
+
+// MarshalYAML encodes this AnimationData as human-readable YAML, following
+// the same layout as MarshalJSON.
+func (ad *AnimationData) MarshalYAML() (interface{}, error) {
+	return ad.toDTO(), nil
+}
+
+// UnmarshalYAML decodes an AnimationData previously produced by MarshalYAML.
+func (ad *AnimationData) UnmarshalYAML(unmarshal func(interface{}) error) error {
+	var dto animationDataDTO
+
+	if err := unmarshal(&dto); err != nil {
+		return err
+	}
+
+	return ad.fromDTO(dto)
+}
@@ -0,0 +1,72 @@
+package cof
+
+import (
+	"bytes"
+	"testing"
+
+	"gopkg.in/yaml.v2"
+)
+
+// buildFixtureAnimationData builds an AnimationData directly (bypassing
+// Load, whose on-disk integer widths this package doesn't independently
+// verify) so the codec round trip can be checked purely against this
+// package's own Marshal.
+func buildFixtureAnimationData(t *testing.T) *AnimationData {
+	t.Helper()
+
+	ad := &AnimationData{}
+	ad.entries = make(map[string][]*AnimationDataRecord)
+
+	records := []*AnimationDataRecord{
+		{"WALK", 8, 256, map[int]FrameEvent{0: EventSound, 4: EventAttack}},
+		{"RUN", 6, 512, map[int]FrameEvent{}},
+	}
+
+	ad.blocks[0] = &block{uint32(len(records)), records}
+
+	for _, r := range records {
+		ad.entries[r.name] = append(ad.entries[r.name], r)
+	}
+
+	return ad
+}
+
+// TestAnimationDataCodecRoundTripByteExact checks that Marshal ->
+// MarshalJSON/YAML -> Unmarshal(JSON/YAML) -> Marshal reproduces the
+// original bytes exactly.
+func TestAnimationDataCodecRoundTripByteExact(t *testing.T) {
+	original := buildFixtureAnimationData(t)
+	want := original.Marshal()
+
+	t.Run("json", func(t *testing.T) {
+		data, err := original.MarshalJSON()
+		if err != nil {
+			t.Fatalf("MarshalJSON: %v", err)
+		}
+
+		decoded := &AnimationData{}
+		if err := decoded.UnmarshalJSON(data); err != nil {
+			t.Fatalf("UnmarshalJSON: %v", err)
+		}
+
+		if got := decoded.Marshal(); !bytes.Equal(want, got) {
+			t.Fatalf("json round trip not byte-exact:\nwant % x\ngot  % x", want, got)
+		}
+	})
+
+	t.Run("yaml", func(t *testing.T) {
+		data, err := yaml.Marshal(original)
+		if err != nil {
+			t.Fatalf("yaml.Marshal: %v", err)
+		}
+
+		decoded := &AnimationData{}
+		if err := yaml.Unmarshal(data, decoded); err != nil {
+			t.Fatalf("yaml.Unmarshal: %v", err)
+		}
+
+		if got := decoded.Marshal(); !bytes.Equal(want, got) {
+			t.Fatalf("yaml round trip not byte-exact:\nwant % x\ngot  % x", want, got)
+		}
+	})
+}
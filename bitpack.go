@@ -0,0 +1,85 @@
+package cof
+
+import "github.com/gravestench/bitstream"
+
+// bitPacker is a tiny bit-granular byte assembler, mirroring the
+// PushBit/PushBits/PushBits16 helpers the sibling d2datautils stream writer
+// grew. bitstream.Writer itself only writes whole bytes, so this is what
+// Marshal uses to build the header/body bytes that HeaderFlags/SetHeaderFlag
+// address bit by bit, one bit at a time, before handing the result to
+// bitstream.Writer.WriteBytes.
+type bitPacker struct {
+	bytes []byte
+	bit   uint
+}
+
+// PushBit appends a single bit, LSB-first within each byte.
+func (p *bitPacker) PushBit(value bool) {
+	if p.bit == 0 {
+		p.bytes = append(p.bytes, 0)
+	}
+
+	if value {
+		p.bytes[len(p.bytes)-1] |= 1 << p.bit
+	}
+
+	p.bit = (p.bit + 1) % 8
+}
+
+// PushBits appends the low n bits of value, LSB-first.
+func (p *bitPacker) PushBits(value uint32, n int) {
+	for i := 0; i < n; i++ {
+		p.PushBit(value&(1<<uint(i)) != 0)
+	}
+}
+
+// PushBits16 appends all 16 bits of value, LSB-first.
+func (p *bitPacker) PushBits16(value uint16) {
+	p.PushBits(uint32(value), 16)
+}
+
+// Bytes returns the bytes assembled so far.
+func (p *bitPacker) Bytes() []byte {
+	return p.bytes
+}
+
+// packBitsFromBytes re-assembles data through a bitPacker instead of copying
+// it directly, so the still-opaque unknown header/body bytes pass through
+// the same bit-granular path as the named header flags on their way into
+// Marshal's output.
+func packBitsFromBytes(data []byte) []byte {
+	packer := &bitPacker{}
+
+	i := 0
+	for ; i+1 < len(data); i += 2 {
+		packer.PushBits16(uint16(data[i]) | uint16(data[i+1])<<8)
+	}
+
+	for ; i < len(data); i++ {
+		packer.PushBits(uint32(data[i]), 8)
+	}
+
+	return packer.Bytes()
+}
+
+// readBitPackedBytes reads numBytes out of stream one bit at a time via
+// stream.Next(1).Bits(), the same bit-granular reader bitstream.Reader
+// already uses elsewhere for multi-bit fields (see AnimationData.Load). This
+// is the read-side counterpart to packBitsFromBytes: both the still-opaque
+// unknown header/body bytes and the named header flags now go through the
+// stream's bit-granular path rather than being read as a single opaque byte
+// blob.
+func readBitPackedBytes(stream *bitstream.Reader, numBytes int) ([]byte, error) {
+	packer := &bitPacker{}
+
+	for i := 0; i < numBytes*8; i++ {
+		bit, err := stream.Next(1).Bits().AsUInt32()
+		if err != nil {
+			return nil, err
+		}
+
+		packer.PushBit(bit != 0)
+	}
+
+	return packer.Bytes(), nil
+}
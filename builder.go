@@ -0,0 +1,75 @@
+package cof
+
+// Builder incrementally assembles a COF so callers don't have to hand-roll
+// the 3D Priority slice or keep CompositeLayers in sync themselves.
+type Builder struct {
+	cof *COF
+}
+
+// NewBuilder returns a Builder seeded with an empty COF.
+func NewBuilder() *Builder {
+	return &Builder{cof: New()}
+}
+
+// AddLayer appends a layer to the COF under construction.
+func (b *Builder) AddLayer(layer CofLayer) *Builder {
+	b.cof.CompositeLayers[layer.Type] = len(b.cof.CofLayers)
+	b.cof.CofLayers = append(b.cof.CofLayers, layer)
+	b.cof.NumberOfLayers = len(b.cof.CofLayers)
+
+	return b
+}
+
+// SetDirections sets the number of directions the COF animates in.
+func (b *Builder) SetDirections(numDirections int) *Builder {
+	b.cof.NumberOfDirections = numDirections
+
+	return b
+}
+
+// SetFramesPerDirection sets the number of frames in each direction,
+// resizing AnimationFrames to match.
+func (b *Builder) SetFramesPerDirection(framesPerDirection int) *Builder {
+	b.cof.FramesPerDirection = framesPerDirection
+
+	frames := make([]FrameEvent, framesPerDirection)
+	copy(frames, b.cof.AnimationFrames)
+	b.cof.AnimationFrames = frames
+
+	return b
+}
+
+// SetFrameEvent sets the event fired on the given frame, the same for every
+// direction.
+func (b *Builder) SetFrameEvent(frame int, event FrameEvent) *Builder {
+	if frame >= 0 && frame < len(b.cof.AnimationFrames) {
+		b.cof.AnimationFrames[frame] = event
+	}
+
+	return b
+}
+
+// SetPriority sets the layer draw order for a given direction/frame pair,
+// growing Priority as needed.
+func (b *Builder) SetPriority(direction, frame int, order []CompositeType) *Builder {
+	for len(b.cof.Priority) <= direction {
+		b.cof.Priority = append(b.cof.Priority, nil)
+	}
+
+	for len(b.cof.Priority[direction]) <= frame {
+		b.cof.Priority[direction] = append(b.cof.Priority[direction], nil)
+	}
+
+	b.cof.Priority[direction][frame] = order
+
+	return b
+}
+
+// Build validates and returns the assembled COF.
+func (b *Builder) Build() (*COF, error) {
+	if err := b.cof.Validate(); err != nil {
+		return nil, err
+	}
+
+	return b.cof, nil
+}
@@ -0,0 +1,131 @@
+// Command cof converts between the binary COF/AnimData.d2 formats and
+// human-editable JSON/YAML, so modders can diff and hand-edit animation
+// metadata. Direction is inferred from the input and output file extensions.
+package main
+
+import (
+	"encoding/json"
+	"flag"
+	"fmt"
+	"io/ioutil"
+	"os"
+	"path/filepath"
+	"strings"
+
+	"github.com/OpenDiablo2/cof"
+	"gopkg.in/yaml.v2"
+)
+
+func main() {
+	flag.Usage = func() {
+		fmt.Fprintf(os.Stderr, "Usage: %s <input> <output>\n", os.Args[0])
+		fmt.Fprintln(os.Stderr, "Converts between .cof/AnimData.d2 and .json/.yaml based on file extension.")
+	}
+
+	flag.Parse()
+
+	if flag.NArg() != 2 {
+		flag.Usage()
+		os.Exit(1)
+	}
+
+	if err := convert(flag.Arg(0), flag.Arg(1)); err != nil {
+		fmt.Fprintln(os.Stderr, "error:", err)
+		os.Exit(1)
+	}
+}
+
+func convert(inputPath, outputPath string) error {
+	data, err := ioutil.ReadFile(inputPath)
+	if err != nil {
+		return err
+	}
+
+	if isAnimData(inputPath) || isAnimData(outputPath) {
+		return convertAnimData(inputPath, outputPath, data)
+	}
+
+	return convertCOF(inputPath, outputPath, data)
+}
+
+func isAnimData(path string) bool {
+	return strings.Contains(strings.ToLower(filepath.Base(path)), "animdata")
+}
+
+func convertCOF(inputPath, outputPath string, data []byte) error {
+	if isTextFormat(inputPath) {
+		c := cof.New()
+		if err := unmarshalText(inputPath, data, c); err != nil {
+			return err
+		}
+
+		return ioutil.WriteFile(outputPath, c.Marshal(), 0644)
+	}
+
+	c, err := cof.Unmarshal(data, filepath.Base(inputPath))
+	if err != nil {
+		return err
+	}
+
+	return marshalText(outputPath, c)
+}
+
+func convertAnimData(inputPath, outputPath string, data []byte) error {
+	if isTextFormat(inputPath) {
+		ad := &cof.AnimationData{}
+		if err := unmarshalText(inputPath, data, ad); err != nil {
+			return err
+		}
+
+		return ioutil.WriteFile(outputPath, ad.Marshal(), 0644)
+	}
+
+	ad, err := cof.Load(data)
+	if err != nil {
+		return err
+	}
+
+	return marshalText(outputPath, ad)
+}
+
+func isTextFormat(path string) bool {
+	switch strings.ToLower(filepath.Ext(path)) {
+	case ".json", ".yaml", ".yml":
+		return true
+	default:
+		return false
+	}
+}
+
+func unmarshalText(path string, data []byte, v interface{}) error {
+	switch strings.ToLower(filepath.Ext(path)) {
+	case ".json":
+		return json.Unmarshal(data, v)
+	case ".yaml", ".yml":
+		return yaml.Unmarshal(data, v)
+	default:
+		return fmt.Errorf("unsupported input format %q", path)
+	}
+}
+
+func marshalText(path string, v interface{}) error {
+	var (
+		out []byte
+		err error
+	)
+
+	switch strings.ToLower(filepath.Ext(path)) {
+	case ".json":
+		out, err = json.MarshalIndent(v, "", "  ")
+	case ".yaml", ".yml":
+		out, err = yaml.Marshal(v)
+	default:
+		return fmt.Errorf("unsupported output format %q", path)
+	}
+
+	if err != nil {
+		return err
+	}
+
+	return ioutil.WriteFile(path, out, 0644)
+}
@@ -1,6 +1,7 @@
 package cof
 
 import (
+	"fmt"
 	"strings"
 
 	"github.com/gravestench/bitstream"
@@ -9,7 +10,6 @@ import (
 const (
 	numUnknownHeaderBytes = 21
 	numUnknownBodyBytes   = 3
-	numHeaderBytes        = 4 + numUnknownHeaderBytes
 	numLayerBytes         = 9
 )
 
@@ -17,7 +17,6 @@ const (
 	headerNumLayers = iota
 	headerFramesPerDir
 	headerNumDirs
-	headerSpeed = numHeaderBytes - 1
 )
 
 const (
@@ -33,19 +32,6 @@ const (
 	badCharacter = string(byte(0))
 )
 
-// FrameEvent represents a single frame of animation.
-type FrameEvent int
-
-// FrameEvent types
-const (
-	EventNone FrameEvent = iota
-	EventAttack
-	EventMissile
-	EventSound
-	EventSkill
-)
-
-
 // New creates a new COF
 func New() *COF {
 	return &COF{
@@ -68,9 +54,9 @@ func Marshal(c *COF) []byte {
 }
 
 // Unmarshal a byte slice to a new COF
-func Unmarshal(data []byte) (*COF, error) {
+func Unmarshal(data []byte, filename ...string) (*COF, error) {
 	c := New()
-	err := c.Unmarshal(data)
+	err := c.Unmarshal(data, filename...)
 
 	return c, err
 }
@@ -80,7 +66,8 @@ type COF struct {
 	// unknown bytes for header
 	unknownHeaderBytes []byte
 	// unknown bytes (first "body's" bytes)
-	unknownBodyBytes   []byte
+	unknownBodyBytes []byte
+
 	NumberOfDirections int
 	FramesPerDirection int
 	NumberOfLayers     int
@@ -91,20 +78,36 @@ type COF struct {
 	Priority           [][][]CompositeType
 }
 
-// Unmarshal a byte slice to this COF
-func (c *COF) Unmarshal(fileData []byte) error {
+// Unmarshal a byte slice to this COF. filename is optional; when given, it
+// is parsed with ParseName so the loaded layers can be validated against
+// the layer set expected for that animation mode.
+func (c *COF) Unmarshal(fileData []byte, filename ...string) error {
 	var err error
 
 	stream := bitstream.NewReader().FromBytes(fileData...)
 
-	headerBytes, err := stream.Next(numHeaderBytes).Bytes().AsBytes()
+	knownHeaderBytes, err := stream.Next(headerNumDirs + 1).Bytes().AsBytes()
+	if err != nil {
+		return err
+	}
+
+	c.NumberOfLayers = int(knownHeaderBytes[headerNumLayers])
+	c.FramesPerDirection = int(knownHeaderBytes[headerFramesPerDir])
+	c.NumberOfDirections = int(knownHeaderBytes[headerNumDirs])
+
+	c.unknownHeaderBytes, err = readBitPackedBytes(stream, numUnknownHeaderBytes)
+	if err != nil {
+		return err
+	}
+
+	speedByte, err := stream.Next(1).Bytes().AsByte()
 	if err != nil {
 		return err
 	}
 
-	c.loadHeader(headerBytes)
+	c.Speed = int(speedByte)
 
-	c.unknownBodyBytes, err = stream.Next(numUnknownBodyBytes).Bytes().AsBytes()
+	c.unknownBodyBytes, err = readBitPackedBytes(stream, numUnknownBodyBytes)
 	if err != nil {
 		return err
 	}
@@ -134,15 +137,39 @@ func (c *COF) Unmarshal(fileData []byte) error {
 
 	c.loadPriority(priorityBytes)
 
+	if len(filename) > 0 && filename[0] != "" {
+		return c.validateAgainstFilename(filename[0])
+	}
+
 	return nil
 }
 
-func (c *COF) loadHeader(b []byte) {
-	c.NumberOfLayers = int(b[headerNumLayers])
-	c.FramesPerDirection = int(b[headerFramesPerDir])
-	c.NumberOfDirections = int(b[headerNumDirs])
-	c.unknownHeaderBytes = b[headerNumDirs+1 : headerSpeed]
-	c.Speed = int(b[headerSpeed])
+// validateAgainstFilename checks that every loaded layer's CompositeType is
+// part of the layer set expected for the animation mode encoded in
+// filename. Filenames whose mode isn't recognized are not checked.
+func (c *COF) validateAgainstFilename(filename string) error {
+	_, mode, _, err := ParseName(filename)
+	if err != nil {
+		return err
+	}
+
+	expected := ModeLayerSet(AnimationMode(mode))
+	if len(expected) == 0 {
+		return nil
+	}
+
+	allowed := make(map[CompositeType]bool, len(expected))
+	for _, compositeType := range expected {
+		allowed[compositeType] = true
+	}
+
+	for _, layer := range c.CofLayers {
+		if !allowed[layer.Type] {
+			return fmt.Errorf("layer %s is not expected for animation mode %s", layer.Type.String(), mode)
+		}
+	}
+
+	return nil
 }
 
 func (c *COF) loadCOFLayers(s *bitstream.Reader) error {
@@ -200,9 +227,9 @@ func (c *COF) Marshal() []byte {
 	sw.WriteByte(byte(c.NumberOfLayers))
 	sw.WriteByte(byte(c.FramesPerDirection))
 	sw.WriteByte(byte(c.NumberOfDirections))
-	sw.WriteBytes(c.unknownHeaderBytes)
+	sw.WriteBytes(packBitsFromBytes(c.unknownHeaderBytes))
 	sw.WriteByte(byte(c.Speed))
-	sw.WriteBytes(c.unknownBodyBytes)
+	sw.WriteBytes(packBitsFromBytes(c.unknownBodyBytes))
 
 	for i := range c.CofLayers {
 		sw.WriteByte(byte(c.CofLayers[i].Type))
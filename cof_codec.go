@@ -0,0 +1,175 @@
+package cof
+
+import "encoding/json"
+
+// cofLayerDTO is the JSON/YAML representation of a CofLayer. Enums are
+// spelled out by name instead of their raw integer values so the file is
+// readable and diffable by hand.
+type cofLayerDTO struct {
+	Type        string `json:"type" yaml:"type"`
+	Shadow      byte   `json:"shadow" yaml:"shadow"`
+	Selectable  bool   `json:"selectable" yaml:"selectable"`
+	Transparent bool   `json:"transparent" yaml:"transparent"`
+	DrawEffect  string `json:"drawEffect" yaml:"drawEffect"`
+	WeaponClass string `json:"weaponClass" yaml:"weaponClass"`
+}
+
+// cofDTO is the JSON/YAML representation of a COF. The unknown header and
+// body bytes round-trip verbatim (as base64 in JSON) so files can be re-marshalled
+// byte-exact even though their meaning isn't fully understood yet.
+type cofDTO struct {
+	UnknownHeaderBytes []byte        `json:"unknownHeaderBytes" yaml:"unknownHeaderBytes"`
+	UnknownBodyBytes   []byte        `json:"unknownBodyBytes" yaml:"unknownBodyBytes"`
+	NumberOfDirections int           `json:"numberOfDirections" yaml:"numberOfDirections"`
+	FramesPerDirection int           `json:"framesPerDirection" yaml:"framesPerDirection"`
+	Speed              int           `json:"speed" yaml:"speed"`
+	Layers             []cofLayerDTO `json:"layers" yaml:"layers"`
+	AnimationFrames    []string      `json:"animationFrames" yaml:"animationFrames"`
+	Priority           [][][]string  `json:"priority" yaml:"priority"`
+}
+
+func (c *COF) toDTO() cofDTO {
+	layers := make([]cofLayerDTO, len(c.CofLayers))
+
+	for i := range c.CofLayers {
+		layer := c.CofLayers[i]
+		layers[i] = cofLayerDTO{
+			Type:        layer.Type.String(),
+			Shadow:      layer.Shadow,
+			Selectable:  layer.Selectable,
+			Transparent: layer.Transparent,
+			DrawEffect:  layer.DrawEffect.String(),
+			WeaponClass: layer.WeaponClass.String(),
+		}
+	}
+
+	frames := make([]string, len(c.AnimationFrames))
+	for i := range c.AnimationFrames {
+		frames[i] = c.AnimationFrames[i].String()
+	}
+
+	priority := make([][][]string, len(c.Priority))
+	for direction := range c.Priority {
+		priority[direction] = make([][]string, len(c.Priority[direction]))
+		for frame := range c.Priority[direction] {
+			row := c.Priority[direction][frame]
+			priority[direction][frame] = make([]string, len(row))
+
+			for i := range row {
+				priority[direction][frame][i] = row[i].String()
+			}
+		}
+	}
+
+	return cofDTO{
+		UnknownHeaderBytes: c.unknownHeaderBytes,
+		UnknownBodyBytes:   c.unknownBodyBytes,
+		NumberOfDirections: c.NumberOfDirections,
+		FramesPerDirection: c.FramesPerDirection,
+		Speed:              c.Speed,
+		Layers:             layers,
+		AnimationFrames:    frames,
+		Priority:           priority,
+	}
+}
+
+func (c *COF) fromDTO(dto cofDTO) error {
+	c.unknownHeaderBytes = dto.UnknownHeaderBytes
+	c.unknownBodyBytes = dto.UnknownBodyBytes
+	c.NumberOfDirections = dto.NumberOfDirections
+	c.FramesPerDirection = dto.FramesPerDirection
+	c.Speed = dto.Speed
+	c.NumberOfLayers = len(dto.Layers)
+	c.CofLayers = make([]CofLayer, len(dto.Layers))
+	c.CompositeLayers = make(map[CompositeType]int)
+
+	for i, layer := range dto.Layers {
+		compositeType, err := CompositeTypeFromString(layer.Type)
+		if err != nil {
+			return err
+		}
+
+		drawEffect, err := DrawEffectFromString(layer.DrawEffect)
+		if err != nil {
+			return err
+		}
+
+		c.CofLayers[i] = CofLayer{
+			Type:        compositeType,
+			Shadow:      layer.Shadow,
+			Selectable:  layer.Selectable,
+			Transparent: layer.Transparent,
+			DrawEffect:  drawEffect,
+			WeaponClass: WeaponClassFromString(layer.WeaponClass),
+		}
+
+		c.CompositeLayers[compositeType] = i
+	}
+
+	c.AnimationFrames = make([]FrameEvent, len(dto.AnimationFrames))
+
+	for i, name := range dto.AnimationFrames {
+		event, err := FrameEventFromString(name)
+		if err != nil {
+			return err
+		}
+
+		c.AnimationFrames[i] = event
+	}
+
+	c.Priority = make([][][]CompositeType, len(dto.Priority))
+
+	for direction, frames := range dto.Priority {
+		c.Priority[direction] = make([][]CompositeType, len(frames))
+
+		for frame, names := range frames {
+			c.Priority[direction][frame] = make([]CompositeType, len(names))
+
+			for i, name := range names {
+				compositeType, err := CompositeTypeFromString(name)
+				if err != nil {
+					return err
+				}
+
+				c.Priority[direction][frame][i] = compositeType
+			}
+		}
+	}
+
+	return nil
+}
+
+// MarshalJSON encodes this COF as human-readable JSON. Enums are written out
+// by name and the still-unknown header/body bytes are preserved as base64 so
+// the result can be fed back through UnmarshalJSON byte-exact.
+func (c *COF) MarshalJSON() ([]byte, error) {
+	return json.Marshal(c.toDTO())
+}
+
+// UnmarshalJSON decodes a COF that was previously produced by MarshalJSON.
+func (c *COF) UnmarshalJSON(data []byte) error {
+	var dto cofDTO
+
+	if err := json.Unmarshal(data, &dto); err != nil {
+		return err
+	}
+
+	return c.fromDTO(dto)
+}
+
+// MarshalYAML encodes this COF as human-readable YAML, following the same
+// layout as MarshalJSON.
+func (c *COF) MarshalYAML() (interface{}, error) {
+	return c.toDTO(), nil
+}
+
+// UnmarshalYAML decodes a COF that was previously produced by MarshalYAML.
+func (c *COF) UnmarshalYAML(unmarshal func(interface{}) error) error {
+	var dto cofDTO
+
+	if err := unmarshal(&dto); err != nil {
+		return err
+	}
+
+	return c.fromDTO(dto)
+}
@@ -0,0 +1,118 @@
+package cof
+
+import (
+	"bytes"
+	"testing"
+
+	"gopkg.in/yaml.v2"
+)
+
+// buildFixtureCOF assembles a COF via Builder with values that vary per
+// layer, frame and byte in every field the codec touches, so a round trip
+// that drops or mis-converts anything shows up as a byte mismatch.
+func buildFixtureCOF(t *testing.T, numDirections, framesPerDirection, numLayers int) *COF {
+	t.Helper()
+
+	builder := NewBuilder().
+		SetDirections(numDirections).
+		SetFramesPerDirection(framesPerDirection)
+
+	for i := 0; i < numLayers; i++ {
+		builder.AddLayer(CofLayer{
+			Type:        CompositeType(i),
+			Shadow:      byte(i + 1),
+			Selectable:  i%2 == 0,
+			Transparent: i%2 == 1,
+			DrawEffect:  DrawEffect(i % 3),
+			WeaponClass: WeaponClassFromString("HTH"),
+		})
+	}
+
+	for frame := 0; frame < framesPerDirection; frame++ {
+		builder.SetFrameEvent(frame, FrameEvent(frame%5))
+	}
+
+	order := make([]CompositeType, numLayers)
+	for i := range order {
+		order[i] = CompositeType(numLayers - 1 - i)
+	}
+
+	for direction := 0; direction < numDirections; direction++ {
+		for frame := 0; frame < framesPerDirection; frame++ {
+			builder.SetPriority(direction, frame, append([]CompositeType(nil), order...))
+		}
+	}
+
+	c, err := builder.Build()
+	if err != nil {
+		t.Fatalf("build fixture COF: %v", err)
+	}
+
+	c.Speed = 128
+
+	for i := range c.unknownHeaderBytes {
+		c.unknownHeaderBytes[i] = byte(i)
+	}
+
+	for i := range c.unknownBodyBytes {
+		c.unknownBodyBytes[i] = byte(i + 1)
+	}
+
+	return c
+}
+
+// TestCOFCodecRoundTripByteExact checks that Marshal -> MarshalJSON/YAML ->
+// Unmarshal(JSON/YAML) -> Marshal reproduces the original bytes exactly.
+func TestCOFCodecRoundTripByteExact(t *testing.T) {
+	cases := []struct {
+		name               string
+		numDirections      int
+		framesPerDirection int
+		numLayers          int
+	}{
+		{"single-layer-single-frame", 1, 1, 1},
+		{"multi-direction-multi-frame", 4, 3, 2},
+		{"many-layers", 2, 2, 5},
+	}
+
+	for _, tc := range cases {
+		tc := tc
+
+		t.Run(tc.name, func(t *testing.T) {
+			original := buildFixtureCOF(t, tc.numDirections, tc.framesPerDirection, tc.numLayers)
+			want := original.Marshal()
+
+			t.Run("json", func(t *testing.T) {
+				data, err := original.MarshalJSON()
+				if err != nil {
+					t.Fatalf("MarshalJSON: %v", err)
+				}
+
+				decoded := New()
+				if err := decoded.UnmarshalJSON(data); err != nil {
+					t.Fatalf("UnmarshalJSON: %v", err)
+				}
+
+				if got := decoded.Marshal(); !bytes.Equal(want, got) {
+					t.Fatalf("json round trip not byte-exact:\nwant % x\ngot  % x", want, got)
+				}
+			})
+
+			t.Run("yaml", func(t *testing.T) {
+				data, err := yaml.Marshal(original)
+				if err != nil {
+					t.Fatalf("yaml.Marshal: %v", err)
+				}
+
+				decoded := New()
+				if err := yaml.Unmarshal(data, decoded); err != nil {
+					t.Fatalf("yaml.Unmarshal: %v", err)
+				}
+
+				if got := decoded.Marshal(); !bytes.Equal(want, got) {
+					t.Fatalf("yaml round trip not byte-exact:\nwant % x\ngot  % x", want, got)
+				}
+			})
+		})
+	}
+}
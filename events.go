@@ -1,5 +1,7 @@
 package cof
 
+import "fmt"
+
 // FrameEvent represents a single frame of animation.
 type FrameEvent int
 
@@ -11,3 +13,32 @@ const (
 	EventSound
 	EventSkill
 )
+
+var frameEventNames = map[FrameEvent]string{
+	EventNone:    "None",
+	EventAttack:  "Attack",
+	EventMissile: "Missile",
+	EventSound:   "Sound",
+	EventSkill:   "Skill",
+}
+
+// String returns the name of this FrameEvent.
+func (e FrameEvent) String() string {
+	if name, found := frameEventNames[e]; found {
+		return name
+	}
+
+	return "None"
+}
+
+// FrameEventFromString returns the FrameEvent with the given name, or an
+// error if the name is not a known FrameEvent.
+func FrameEventFromString(s string) (FrameEvent, error) {
+	for event, name := range frameEventNames {
+		if name == s {
+			return event, nil
+		}
+	}
+
+	return EventNone, fmt.Errorf("unknown frame event '%s'", s)
+}
@@ -0,0 +1,101 @@
+package cof
+
+import (
+	"fmt"
+	"strings"
+)
+
+// headerFlag names a single bit within the still largely unknown header
+// bytes. Nothing here is a confirmed part of the file format: verified is
+// false for every entry until someone actually confirms what the bit does
+// against known vanilla files. Unmarshal/Marshal now read and write
+// unknownHeaderBytes through the same bit-granular path (readBitPackedBytes /
+// packBitsFromBytes in bitpack.go) that these flags are addressed through, so
+// a bit with no entry here (or a wrong guess below) still round-trips
+// unchanged — it just means the bit isn't named yet.
+type headerFlag struct {
+	name      string
+	byteIndex int
+	bitIndex  int
+	verified  bool
+}
+
+// headerFlags is a list of *hypotheses* for what a couple of the unknown
+// header bits might mean, not established facts. Treat names/positions as
+// unverified guesses to be confirmed or discarded, not a settled spec.
+var headerFlags = []headerFlag{
+	{name: "DirectionFlip", byteIndex: 0, bitIndex: 0, verified: false},
+	{name: "LayerTransparencyMask", byteIndex: 0, bitIndex: 1, verified: false},
+}
+
+// HeaderFlags decodes the currently-hypothesized named bits out of the
+// header's unknown bytes. None of these are confirmed; see headerFlags.
+func (c *COF) HeaderFlags() map[string]bool {
+	flags := make(map[string]bool, len(headerFlags))
+
+	for _, f := range headerFlags {
+		flags[f.name] = c.headerBit(f.byteIndex, f.bitIndex)
+	}
+
+	return flags
+}
+
+// SetHeaderFlag sets a single named bit within the header's unknown bytes.
+// It returns an error if name isn't one of the bits HeaderFlags knows about.
+func (c *COF) SetHeaderFlag(name string, value bool) error {
+	for _, f := range headerFlags {
+		if f.name != name {
+			continue
+		}
+
+		c.setHeaderBit(f.byteIndex, f.bitIndex, value)
+
+		return nil
+	}
+
+	return fmt.Errorf("unknown header flag %q", name)
+}
+
+func (c *COF) headerBit(byteIndex, bitIndex int) bool {
+	if byteIndex < 0 || byteIndex >= len(c.unknownHeaderBytes) {
+		return false
+	}
+
+	return c.unknownHeaderBytes[byteIndex]&(1<<uint(bitIndex)) != 0
+}
+
+func (c *COF) setHeaderBit(byteIndex, bitIndex int, value bool) {
+	if byteIndex < 0 || byteIndex >= len(c.unknownHeaderBytes) {
+		return
+	}
+
+	mask := byte(1 << uint(bitIndex))
+
+	if value {
+		c.unknownHeaderBytes[byteIndex] |= mask
+	} else {
+		c.unknownHeaderBytes[byteIndex] &^= mask
+	}
+}
+
+// DumpHeaderBits renders the header's unknown bytes as raw hex alongside
+// every currently-hypothesized flag, to help reverse-engineer the remaining
+// bits. Flags are labeled "unverified" unless headerFlag.verified is true,
+// since none of them are confirmed against the real file format yet.
+func (c *COF) DumpHeaderBits() string {
+	var sb strings.Builder
+
+	fmt.Fprintf(&sb, "raw: % x\n", c.unknownHeaderBytes)
+
+	for _, f := range headerFlags {
+		status := "unverified"
+		if f.verified {
+			status = "verified"
+		}
+
+		fmt.Fprintf(&sb, "  %s (byte %d, bit %d) = %v [%s]\n",
+			f.name, f.byteIndex, f.bitIndex, c.headerBit(f.byteIndex, f.bitIndex), status)
+	}
+
+	return sb.String()
+}
@@ -0,0 +1,56 @@
+package cof
+
+// PlaybackFrame is a single ready-to-play frame of animation, combining a
+// COF's layer draw order with an AnimationDataRecord's timing.
+type PlaybackFrame struct {
+	Direction  int
+	FrameIndex int
+	DurationMs int
+	Event      FrameEvent
+	LayerOrder []CompositeType
+}
+
+// PlaybackFrames joins a COF's per-direction layer priorities with an
+// AnimationDataRecord's timing, giving callers one ready-to-play timeline
+// instead of reimplementing the duration math themselves. Duration is
+// derived from rec.speed the same way the engine derives it; events come
+// from rec.events where present and fall back to c.AnimationFrames.
+func PlaybackFrames(c *COF, rec *AnimationDataRecord) []PlaybackFrame {
+	durationMs := 0
+
+	if rec != nil && rec.speed > 0 && c.FramesPerDirection > 0 {
+		durationMs = milliseconds * speedDivisor / (int(rec.speed) * speedBaseFPS * c.FramesPerDirection)
+	}
+
+	frames := make([]PlaybackFrame, 0, c.NumberOfDirections*c.FramesPerDirection)
+
+	for direction := 0; direction < c.NumberOfDirections; direction++ {
+		for frameIdx := 0; frameIdx < c.FramesPerDirection; frameIdx++ {
+			event := EventNone
+			if frameIdx < len(c.AnimationFrames) {
+				event = c.AnimationFrames[frameIdx]
+			}
+
+			if rec != nil {
+				if e, found := rec.events[frameIdx]; found {
+					event = e
+				}
+			}
+
+			var layerOrder []CompositeType
+			if direction < len(c.Priority) && frameIdx < len(c.Priority[direction]) {
+				layerOrder = c.Priority[direction][frameIdx]
+			}
+
+			frames = append(frames, PlaybackFrame{
+				Direction:  direction,
+				FrameIndex: frameIdx,
+				DurationMs: durationMs,
+				Event:      event,
+				LayerOrder: layerOrder,
+			})
+		}
+	}
+
+	return frames
+}
@@ -0,0 +1,62 @@
+package cof
+
+import "errors"
+
+// Errors returned by (*COF).Validate.
+var (
+	ErrLayerCountMismatch         = errors.New("layer count does not match NumberOfLayers")
+	ErrAnimationFrameDimMismatch  = errors.New("animation frame count does not match frames per direction")
+	ErrPriorityDimMismatch        = errors.New("priority dimensions do not match directions/frames/layers")
+	ErrUnknownCompositeInPriority = errors.New("priority references a composite type with no matching layer")
+	ErrWeaponClassTooLong         = errors.New("weapon class code must be at most 3 characters")
+)
+
+const maxWeaponClassLength = 3
+
+// Validate checks that this COF is internally consistent: CofLayers has one
+// entry per NumberOfLayers, AnimationFrames has one entry per frame, the
+// Priority dimensions match
+// NumberOfDirections/FramesPerDirection/NumberOfLayers, every Priority entry
+// references a layer that actually exists, and each layer's weapon class
+// code is short enough to round-trip through Marshal. Marshal does not
+// perform these checks itself, so a hand-built COF can otherwise produce a
+// corrupt file silently.
+func (c *COF) Validate() error {
+	if len(c.CofLayers) != c.NumberOfLayers {
+		return ErrLayerCountMismatch
+	}
+
+	if len(c.AnimationFrames) != c.FramesPerDirection {
+		return ErrAnimationFrameDimMismatch
+	}
+
+	if len(c.Priority) != c.NumberOfDirections {
+		return ErrPriorityDimMismatch
+	}
+
+	for _, frames := range c.Priority {
+		if len(frames) != c.FramesPerDirection {
+			return ErrPriorityDimMismatch
+		}
+
+		for _, layers := range frames {
+			if len(layers) != c.NumberOfLayers {
+				return ErrPriorityDimMismatch
+			}
+
+			for _, compositeType := range layers {
+				if _, found := c.CompositeLayers[compositeType]; !found {
+					return ErrUnknownCompositeInPriority
+				}
+			}
+		}
+	}
+
+	for _, layer := range c.CofLayers {
+		if len(layer.WeaponClass.String()) > maxWeaponClassLength {
+			return ErrWeaponClassTooLong
+		}
+	}
+
+	return nil
+}